@@ -0,0 +1,39 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LifecycleTimeouts overrides the launch/registration timeouts the NodeClaim lifecycle
+// controller's Liveness sub-reconciler enforces. It can be set on NodePool.Spec.Lifecycle and on
+// NodeClaim.Spec.Lifecycle; a value set on the NodeClaim takes precedence over its NodePool's.
+// It is not copied from the NodePool onto the NodeClaim when the NodeClaim is created, so a
+// NodeClaim without its own value follows later edits to its NodePool.
+type LifecycleTimeouts struct {
+	// LaunchTimeout is how long Liveness waits for the NodeClaim to report Launched before
+	// terminating it. Must be positive, and less than or equal to RegistrationTimeout. Defaults
+	// to 5m when unset.
+	// +optional
+	LaunchTimeout *metav1.Duration `json:"launchTimeout,omitempty"`
+	// RegistrationTimeout is how long Liveness waits for the NodeClaim to report Registered
+	// before terminating it. Must be positive, and greater than or equal to LaunchTimeout.
+	// Defaults to 15m when unset.
+	// +optional
+	RegistrationTimeout *metav1.Duration `json:"registrationTimeout,omitempty"`
+}
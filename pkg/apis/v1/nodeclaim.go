@@ -0,0 +1,111 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/operatorpkg/status"
+)
+
+const (
+	// CapacityTypeLabelKey is set on NodeClaims (and the Nodes they back) to record which
+	// capacity type (e.g. on-demand, spot) they were launched as.
+	CapacityTypeLabelKey = Group + "/capacity-type"
+)
+
+const (
+	ConditionTypeLaunched   = "Launched"
+	ConditionTypeRegistered = "Registered"
+	// ConditionTypeRegistering is a standalone informational condition, not part of the Ready
+	// set: it's False, with a reason/message taken from the most recent non-healthy Node
+	// condition (Ready, NetworkUnavailable, MemoryPressure, DiskPressure, PIDPressure), while a
+	// NodeClaim that hasn't yet reached ConditionTypeRegistered=True is stalled on something
+	// observable. It's left untouched (neither True nor False) once the Node looks healthy, since
+	// there's nothing to report.
+	ConditionTypeRegistering = "Registering"
+)
+
+// NodeClaim is the Schema for the NodeClaims API.
+type NodeClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeClaimSpec   `json:"spec,omitempty"`
+	Status NodeClaimStatus `json:"status,omitempty"`
+}
+
+// NodeClaimSpec describes the desired state of the NodeClaim.
+type NodeClaimSpec struct {
+	// Lifecycle overrides the owning NodePool's Spec.Lifecycle for this NodeClaim. It is not
+	// filled in from the NodePool at creation time; where a field is unset, the NodePool's
+	// current value (or the default) applies.
+	// +optional
+	Lifecycle LifecycleTimeouts `json:"lifecycle,omitempty"`
+}
+
+// NodeClaimStatus tracks the observed state of the NodeClaim.
+type NodeClaimStatus struct {
+	// Conditions contains signals for health and readiness.
+	// +optional
+	Conditions []status.Condition `json:"conditions,omitempty"`
+	// ProviderID is the identifier for the provider instance backing this NodeClaim, set once
+	// the instance has launched. It's used to look up the backing Node.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+}
+
+// ConditionTypeRegistering is deliberately excluded from the Ready set: it's only ever set when
+// registration is stalled, so folding it into the Ready conjunction would leave every normally
+// registering NodeClaim stuck at Ready=Unknown.
+var nodeClaimStatusConditions = status.NewReadyConditions(
+	ConditionTypeLaunched,
+	ConditionTypeRegistered,
+)
+
+func (in *NodeClaim) StatusConditions() status.ConditionSet {
+	return nodeClaimStatusConditions.For(in)
+}
+
+func (in *NodeClaim) GetConditions() []status.Condition {
+	return in.Status.Conditions
+}
+
+func (in *NodeClaim) SetConditions(conditions []status.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *NodeClaim) DeepCopy() *NodeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeClaim)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.TypeMeta = in.TypeMeta
+	out.Spec = in.Spec
+	if in.Spec.Lifecycle.LaunchTimeout != nil {
+		v := *in.Spec.Lifecycle.LaunchTimeout
+		out.Spec.Lifecycle.LaunchTimeout = &v
+	}
+	if in.Spec.Lifecycle.RegistrationTimeout != nil {
+		v := *in.Spec.Lifecycle.RegistrationTimeout
+		out.Spec.Lifecycle.RegistrationTimeout = &v
+	}
+	out.Status.ProviderID = in.Status.ProviderID
+	out.Status.Conditions = append([]status.Condition(nil), in.Status.Conditions...)
+	return out
+}
@@ -0,0 +1,124 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/awslabs/operatorpkg/status"
+)
+
+const (
+	// Group is the API group this package's types are registered under.
+	Group = "karpenter.sh"
+	// NodePoolLabelKey is set on NodeClaims (and the Nodes they back) to identify the NodePool
+	// that provisioned them.
+	NodePoolLabelKey = Group + "/nodepool"
+)
+
+const (
+	// ConditionTypeNodeRegistrationHealthy is False on a NodePool once one of its NodeClaims has
+	// failed to launch or register.
+	ConditionTypeNodeRegistrationHealthy = "NodeRegistrationHealthy"
+	// ConditionTypeProvisioningPaused is True on a NodePool that has been quarantined after
+	// crossing defaultConsecutiveFailureThreshold consecutive registration failures. The
+	// provisioning controller skips paused NodePools until their backoff cooldown elapses.
+	ConditionTypeProvisioningPaused = "ProvisioningPaused"
+)
+
+// NodePool is the Schema for the NodePools API.
+type NodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodePoolSpec   `json:"spec,omitempty"`
+	Status NodePoolStatus `json:"status,omitempty"`
+}
+
+// NodePoolSpec configures how NodeClaims are launched and managed for this NodePool.
+type NodePoolSpec struct {
+	// Lifecycle overrides the default launch/registration timeouts for NodeClaims created from
+	// this NodePool.
+	// +optional
+	Lifecycle LifecycleTimeouts `json:"lifecycle,omitempty"`
+}
+
+// NodePoolStatus tracks the observed state of the NodePool.
+type NodePoolStatus struct {
+	// Conditions contains signals for health and readiness.
+	// +optional
+	Conditions []status.Condition `json:"conditions,omitempty"`
+	// ConsecutiveRegistrationFailures is a rolling count of NodeClaims belonging to this NodePool
+	// that have, one after another, been disrupted for a launch/registration timeout or a
+	// kubelet-unhealthy reason. It resets to 0 the next time one of this NodePool's NodeClaims
+	// registers successfully.
+	// +optional
+	ConsecutiveRegistrationFailures int `json:"consecutiveRegistrationFailures,omitempty"`
+	// LastFailureTime is the timestamp of the most recent consecutive registration failure.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// LastFailedNodeClaimUID is the UID of the last NodeClaim counted against
+	// ConsecutiveRegistrationFailures. It lets the lifecycle controller tell a new failing
+	// NodeClaim apart from a retried reconcile of the one it already counted, since a NodeClaim
+	// that's already been marked for deletion can still be reconciled again before the delete lands.
+	// +optional
+	LastFailedNodeClaimUID types.UID `json:"lastFailedNodeClaimUID,omitempty"`
+}
+
+var nodePoolStatusConditions = status.NewReadyConditions(
+	ConditionTypeNodeRegistrationHealthy,
+	ConditionTypeProvisioningPaused,
+)
+
+func (in *NodePool) StatusConditions() status.ConditionSet {
+	return nodePoolStatusConditions.For(in)
+}
+
+func (in *NodePool) GetConditions() []status.Condition {
+	return in.Status.Conditions
+}
+
+func (in *NodePool) SetConditions(conditions []status.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.TypeMeta = in.TypeMeta
+	out.Spec = in.Spec
+	if in.Spec.Lifecycle.LaunchTimeout != nil {
+		v := *in.Spec.Lifecycle.LaunchTimeout
+		out.Spec.Lifecycle.LaunchTimeout = &v
+	}
+	if in.Spec.Lifecycle.RegistrationTimeout != nil {
+		v := *in.Spec.Lifecycle.RegistrationTimeout
+		out.Spec.Lifecycle.RegistrationTimeout = &v
+	}
+	out.Status.ConsecutiveRegistrationFailures = in.Status.ConsecutiveRegistrationFailures
+	out.Status.LastFailedNodeClaimUID = in.Status.LastFailedNodeClaimUID
+	out.Status.Conditions = append([]status.Condition(nil), in.Status.Conditions...)
+	if in.Status.LastFailureTime != nil {
+		v := *in.Status.LastFailureTime
+		out.Status.LastFailureTime = &v
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"knative.dev/pkg/apis"
+)
+
+// defaultLaunchTimeout and defaultRegistrationTimeout mirror the package defaults the NodeClaim
+// lifecycle controller's Liveness sub-reconciler falls back to (pkg/controllers/nodeclaim/lifecycle)
+// when a NodePool doesn't set Spec.Lifecycle.{Launch,Registration}Timeout. They're duplicated here,
+// rather than imported, so pkg/apis/v1 doesn't depend on pkg/controllers.
+const (
+	defaultLaunchTimeout       = 5 * time.Minute
+	defaultRegistrationTimeout = 15 * time.Minute
+)
+
+// Validate is called by the NodePool validating webhook on create and update.
+func (in *NodePool) Validate() (errs *apis.FieldError) {
+	return errs.Also(in.Spec.validate())
+}
+
+func (in *NodePoolSpec) validate() (errs *apis.FieldError) {
+	return errs.Also(in.Lifecycle.validate().ViaField("lifecycle"))
+}
+
+// validate enforces that LaunchTimeout and RegistrationTimeout, when set, are both positive and
+// that the *effective* RegistrationTimeout (the set value, or defaultRegistrationTimeout when
+// unset) is not shorter than the effective LaunchTimeout — otherwise the registration timer could
+// fire before the launch timer ever gets a chance to. Comparing effective rather than raw values
+// matters because e.g. LaunchTimeout=20m with RegistrationTimeout unset would otherwise pass even
+// though the effective registration budget (defaultRegistrationTimeout, 15m) is shorter.
+func (in *LifecycleTimeouts) validate() (errs *apis.FieldError) {
+	if in.LaunchTimeout != nil && in.LaunchTimeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(in.LaunchTimeout.Duration, "launchTimeout"))
+	}
+	if in.RegistrationTimeout != nil && in.RegistrationTimeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(in.RegistrationTimeout.Duration, "registrationTimeout"))
+	}
+	effectiveLaunchTimeout := defaultLaunchTimeout
+	if in.LaunchTimeout != nil {
+		effectiveLaunchTimeout = in.LaunchTimeout.Duration
+	}
+	effectiveRegistrationTimeout := defaultRegistrationTimeout
+	if in.RegistrationTimeout != nil {
+		effectiveRegistrationTimeout = in.RegistrationTimeout.Duration
+	}
+	if effectiveRegistrationTimeout < effectiveLaunchTimeout {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("registrationTimeout (%s) must be greater than or equal to launchTimeout (%s)",
+				effectiveRegistrationTimeout, effectiveLaunchTimeout),
+			Paths: []string{"registrationTimeout"},
+		})
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func duration(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
+
+func TestLifecycleTimeoutsValidate(t *testing.T) {
+	cases := map[string]struct {
+		timeouts LifecycleTimeouts
+		wantErr  bool
+	}{
+		"both unset": {
+			timeouts: LifecycleTimeouts{},
+		},
+		"valid overrides": {
+			timeouts: LifecycleTimeouts{LaunchTimeout: duration(time.Minute), RegistrationTimeout: duration(10 * time.Minute)},
+		},
+		"equal overrides allowed": {
+			timeouts: LifecycleTimeouts{LaunchTimeout: duration(10 * time.Minute), RegistrationTimeout: duration(10 * time.Minute)},
+		},
+		"non-positive launch timeout": {
+			timeouts: LifecycleTimeouts{LaunchTimeout: duration(0)},
+			wantErr:  true,
+		},
+		"negative registration timeout": {
+			timeouts: LifecycleTimeouts{RegistrationTimeout: duration(-time.Minute)},
+			wantErr:  true,
+		},
+		"registration shorter than launch, both set": {
+			timeouts: LifecycleTimeouts{LaunchTimeout: duration(10 * time.Minute), RegistrationTimeout: duration(5 * time.Minute)},
+			wantErr:  true,
+		},
+		"launch override alone exceeds the default registration timeout": {
+			// LaunchTimeout=20m with RegistrationTimeout left unset must still fail: the
+			// effective registration budget is the 15m default, shorter than 20m.
+			timeouts: LifecycleTimeouts{LaunchTimeout: duration(20 * time.Minute)},
+			wantErr:  true,
+		},
+		"registration override alone below the default launch timeout": {
+			// RegistrationTimeout=1m with LaunchTimeout left unset must still fail: the
+			// effective launch budget is the 5m default, longer than 1m.
+			timeouts: LifecycleTimeouts{RegistrationTimeout: duration(time.Minute)},
+			wantErr:  true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			errs := tc.timeouts.validate()
+			if tc.wantErr && errs == nil {
+				t.Fatalf("validate() = nil, want an error")
+			}
+			if !tc.wantErr && errs != nil {
+				t.Fatalf("validate() = %v, want no error", errs)
+			}
+		})
+	}
+}
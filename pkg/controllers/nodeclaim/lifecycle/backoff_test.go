@@ -0,0 +1,45 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProvisioningBackoffFor(t *testing.T) {
+	cases := map[string]struct {
+		consecutiveFailures int
+		want                time.Duration
+	}{
+		"below threshold":     {consecutiveFailures: 0, want: 0},
+		"one below threshold": {consecutiveFailures: defaultConsecutiveFailureThreshold - 1, want: 0},
+		"at threshold":        {consecutiveFailures: defaultConsecutiveFailureThreshold, want: time.Minute},
+		"one past threshold":  {consecutiveFailures: defaultConsecutiveFailureThreshold + 1, want: 2 * time.Minute},
+		"two past threshold":  {consecutiveFailures: defaultConsecutiveFailureThreshold + 2, want: 4 * time.Minute},
+		"caps at max":         {consecutiveFailures: defaultConsecutiveFailureThreshold + 10, want: provisioningBackoffMax},
+		"overflow guard caps": {consecutiveFailures: defaultConsecutiveFailureThreshold + 100, want: provisioningBackoffMax},
+		"way below threshold": {consecutiveFailures: -1, want: 0},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ProvisioningBackoffFor(tc.consecutiveFailures); got != tc.want {
+				t.Errorf("ProvisioningBackoffFor(%d) = %s, want %s", tc.consecutiveFailures, got, tc.want)
+			}
+		})
+	}
+}
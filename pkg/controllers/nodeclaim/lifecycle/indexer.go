@@ -0,0 +1,43 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// providerIDFieldIndex is the field index nodeForNodeClaim queries to find the Node backing a
+// NodeClaim without listing every Node in the cluster.
+const providerIDFieldIndex = "spec.providerID"
+
+// RegisterProviderIDIndexer indexes Nodes by spec.providerID so nodeForNodeClaim can look one up
+// by the NodeClaim's Status.ProviderID in O(1) instead of listing and filtering every Node.
+// Nothing in this package calls it; it has to be registered on the manager before NodeClaims
+// are reconciled.
+func RegisterProviderIDIndexer(ctx context.Context, mgr manager.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &corev1.Node{}, providerIDFieldIndex, func(o client.Object) []string {
+		node := o.(*corev1.Node)
+		if node.Spec.ProviderID == "" {
+			return nil
+		}
+		return []string{node.Spec.ProviderID}
+	})
+}
@@ -18,9 +18,12 @@ package lifecycle
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
 
@@ -42,14 +45,45 @@ type Liveness struct {
 // registrationTimeout is a heuristic time that we expect the node to register within
 // launchTimeout is a heuristic time that we expect to be able to launch within
 // If we don't see the node within this time, then we should delete the NodeClaim and try again
+// These are also the defaults used when neither the NodeClaim nor its NodePool sets
+// Spec.Lifecycle.RegistrationTimeout or Spec.Lifecycle.LaunchTimeout. NodePool-level overrides of
+// these defaults are validated (positive, and RegistrationTimeout >= LaunchTimeout, comparing
+// effective values so one side defaulting doesn't skip the comparison) by NodePool.Validate in
+// pkg/apis/v1/nodepool_validation.go. See launchTimeoutFor/registrationTimeoutFor below for the
+// precedence between the NodeClaim's own override and its NodePool's.
 
 const (
 	registrationTimeout       = time.Minute * 15
 	registrationTimeoutReason = "registration_timeout"
 	launchTimeout             = time.Minute * 5
 	launchTimeoutReason       = "launch_timeout"
+
+	// registrationPollInterval is how often we re-check the backing Node's conditions while
+	// waiting for registration, instead of blindly parking until registrationTimeout elapses.
+	registrationPollInterval = 30 * time.Second
+	// kubeletUnhealthyReason is used instead of registrationTimeoutReason when we short-circuit
+	// the registration timer because the kubelet reported a condition it can't recover from.
+	kubeletUnhealthyReason = "kubelet_unhealthy"
+
+	// defaultConsecutiveFailureThreshold is the number of consecutive registration failures at
+	// which we pause provisioning on a NodePool instead of relaunching into it forever.
+	defaultConsecutiveFailureThreshold = 3
+	// provisioningPausedReason is the ConditionTypeProvisioningPaused reason set once a NodePool
+	// crosses defaultConsecutiveFailureThreshold.
+	provisioningPausedReason = "ConsecutiveRegistrationFailures"
+	// provisioningBackoffBase and provisioningBackoffMax bound the exponential cooldown applied
+	// once a NodePool crosses defaultConsecutiveFailureThreshold: 1m, 2m, 4m, ... capped at 30m.
+	provisioningBackoffBase = time.Minute
+	provisioningBackoffMax  = 30 * time.Minute
 )
 
+// terminalKubeletConditionReasons are Ready-condition reasons reported by the kubelet that mean
+// the node will never finish registering without operator intervention (e.g. a bad config), so
+// there's no reason to keep waiting out the rest of the registration timeout.
+var terminalKubeletConditionReasons = map[string]bool{
+	"KubeletConfigFailed": true,
+}
+
 type NodeClaimTimeout struct {
 	duration time.Duration
 	reason   string
@@ -69,19 +103,36 @@ var (
 //nolint:gocyclo
 func (l *Liveness) Reconcile(ctx context.Context, nodeClaim *v1.NodeClaim) (reconcile.Result, error) {
 	registered := nodeClaim.StatusConditions().Get(v1.ConditionTypeRegistered)
+	nodePool, err := l.getNodePool(ctx, nodeClaim)
+	if client.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, err
+	}
 	if registered.IsTrue() {
+		// A successful registration means whatever was causing prior failures is no longer
+		// happening, so the NodePool gets to provision again immediately.
+		if err := l.resetNodePoolProvisioningBackoff(ctx, nodePool); client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
 		return reconcile.Result{}, nil
 	}
+	effectiveLaunchTimeout := launchTimeoutFor(nodeClaim, nodePool)
+	effectiveRegistrationTimeout := registrationTimeoutFor(nodeClaim, nodePool)
 	launched := nodeClaim.StatusConditions().Get(v1.ConditionTypeLaunched)
 	if launched == nil {
 		return reconcile.Result{Requeue: true}, nil
 	}
 	if !launched.IsTrue() {
-		if timeUntilTimeout := launchTimeout - l.clock.Since(launched.LastTransitionTime.Time); timeUntilTimeout > 0 {
+		if timeUntilTimeout := effectiveLaunchTimeout - l.clock.Since(launched.LastTransitionTime.Time); timeUntilTimeout > 0 {
 			// This should never occur because if we failed to launch we requeue the object with error instead of this requeueAfter
 			return reconcile.Result{RequeueAfter: timeUntilTimeout}, nil
 		}
-		if err := l.deleteNodeClaimForTimeout(ctx, LaunchTimeout, nodeClaim); err != nil {
+		if err := l.updateNodePoolRegistrationHealth(ctx, nodePool, nodeClaim); client.IgnoreNotFound(err) != nil {
+			if errors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		if err := l.deleteNodeClaimForTimeout(ctx, NodeClaimTimeout{duration: effectiveLaunchTimeout, reason: launchTimeoutReason}, nodePool, nodeClaim); err != nil {
 			if client.IgnoreNotFound(err) != nil {
 				return reconcile.Result{}, err
 			}
@@ -91,19 +142,41 @@ func (l *Liveness) Reconcile(ctx context.Context, nodeClaim *v1.NodeClaim) (reco
 	if registered == nil {
 		return reconcile.Result{Requeue: true}, nil
 	}
+	terminal, err := l.pollRegistrationProgress(ctx, nodeClaim)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if terminal {
+		if err := l.updateNodePoolRegistrationHealth(ctx, nodePool, nodeClaim); client.IgnoreNotFound(err) != nil {
+			if errors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		if err := l.deleteNodeClaimForTimeout(ctx, NodeClaimTimeout{duration: effectiveRegistrationTimeout, reason: kubeletUnhealthyReason}, nodePool, nodeClaim); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
 	// If the Registered statusCondition hasn't gone True during the timeout since we first updated it, we should terminate the NodeClaim
 	// NOTE: Timeout has to be stored and checked in the same place since l.clock can advance after the check causing a race
-	if timeUntilTimeout := registrationTimeout - l.clock.Since(registered.LastTransitionTime.Time); timeUntilTimeout > 0 {
-		return reconcile.Result{RequeueAfter: timeUntilTimeout}, nil
+	if timeUntilTimeout := effectiveRegistrationTimeout - l.clock.Since(registered.LastTransitionTime.Time); timeUntilTimeout > 0 {
+		requeueAfter := registrationPollInterval
+		if timeUntilTimeout < requeueAfter {
+			requeueAfter = timeUntilTimeout
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
-	if err := l.updateNodePoolRegistrationHealth(ctx, nodeClaim); client.IgnoreNotFound(err) != nil {
+	if err := l.updateNodePoolRegistrationHealth(ctx, nodePool, nodeClaim); client.IgnoreNotFound(err) != nil {
 		if errors.IsConflict(err) {
 			return reconcile.Result{Requeue: true}, nil
 		}
 		return reconcile.Result{}, err
 	}
 	// Delete the NodeClaim if we believe the NodeClaim won't register since we haven't seen the node
-	if err := l.deleteNodeClaimForTimeout(ctx, RegistrationTimeout, nodeClaim); err != nil {
+	if err := l.deleteNodeClaimForTimeout(ctx, NodeClaimTimeout{duration: effectiveRegistrationTimeout, reason: registrationTimeoutReason}, nodePool, nodeClaim); err != nil {
 		if client.IgnoreNotFound(err) != nil {
 			return reconcile.Result{}, err
 		}
@@ -112,44 +185,209 @@ func (l *Liveness) Reconcile(ctx context.Context, nodeClaim *v1.NodeClaim) (reco
 	return reconcile.Result{}, nil
 }
 
-// updateNodePoolRegistrationHealth sets the NodeRegistrationHealthy=False
-// on the NodePool if the nodeClaim fails to launch/register
-func (l *Liveness) updateNodePoolRegistrationHealth(ctx context.Context, nodeClaim *v1.NodeClaim) error {
+// getNodePool returns the NodeClaim's owning NodePool, or nil if the NodeClaim isn't labeled with
+// one. Used both to update NodePool-level registration health and, via launchTimeoutFor /
+// registrationTimeoutFor, as the fallback source for the effective timeouts when the NodeClaim
+// doesn't set its own Spec.Lifecycle values.
+func (l *Liveness) getNodePool(ctx context.Context, nodeClaim *v1.NodeClaim) (*v1.NodePool, error) {
 	nodePoolName := nodeClaim.Labels[v1.NodePoolLabelKey]
-	if nodePoolName != "" {
-		nodePool := &v1.NodePool{}
-		if err := l.kubeClient.Get(ctx, types.NamespacedName{Name: nodePoolName}, nodePool); err != nil {
-			return err
+	if nodePoolName == "" {
+		return nil, nil
+	}
+	nodePool := &v1.NodePool{}
+	if err := l.kubeClient.Get(ctx, types.NamespacedName{Name: nodePoolName}, nodePool); err != nil {
+		return nil, err
+	}
+	return nodePool, nil
+}
+
+// launchTimeoutFor returns nodeClaim.Spec.Lifecycle.LaunchTimeout when set, then
+// nodePool.Spec.Lifecycle.LaunchTimeout, falling back to the package default otherwise.
+// Nothing in this controller copies NodePool.Spec.Lifecycle onto NodeClaim.Spec.Lifecycle, so
+// unless the NodeClaim carries its own value, the NodePool's current value is read here and an
+// edit to the NodePool changes the budget of its existing NodeClaims.
+func launchTimeoutFor(nodeClaim *v1.NodeClaim, nodePool *v1.NodePool) time.Duration {
+	if nodeClaim.Spec.Lifecycle.LaunchTimeout != nil {
+		return nodeClaim.Spec.Lifecycle.LaunchTimeout.Duration
+	}
+	if nodePool != nil && nodePool.Spec.Lifecycle.LaunchTimeout != nil {
+		return nodePool.Spec.Lifecycle.LaunchTimeout.Duration
+	}
+	return launchTimeout
+}
+
+// registrationTimeoutFor returns nodeClaim.Spec.Lifecycle.RegistrationTimeout when set, then
+// nodePool.Spec.Lifecycle.RegistrationTimeout, falling back to the package default otherwise. See
+// launchTimeoutFor for why the NodePool is also consulted.
+func registrationTimeoutFor(nodeClaim *v1.NodeClaim, nodePool *v1.NodePool) time.Duration {
+	if nodeClaim.Spec.Lifecycle.RegistrationTimeout != nil {
+		return nodeClaim.Spec.Lifecycle.RegistrationTimeout.Duration
+	}
+	if nodePool != nil && nodePool.Spec.Lifecycle.RegistrationTimeout != nil {
+		return nodePool.Spec.Lifecycle.RegistrationTimeout.Duration
+	}
+	return registrationTimeout
+}
+
+// pollRegistrationProgress looks up the Node backing nodeClaim by its provider ID and surfaces
+// the most relevant non-healthy NodeCondition as ConditionTypeRegistering=False, so operators get
+// an early signal instead of waiting out the full registration timeout blind. It returns true if
+// the kubelet reported a terminal condition, meaning registration should be abandoned immediately
+// rather than waiting for the rest of the registration timeout to elapse.
+func (l *Liveness) pollRegistrationProgress(ctx context.Context, nodeClaim *v1.NodeClaim) (bool, error) {
+	node, err := l.nodeForNodeClaim(ctx, nodeClaim)
+	if err != nil {
+		return false, err
+	}
+	if node == nil {
+		return false, nil
+	}
+	reason, message, terminal := registeringConditionFromNode(node)
+	if reason != "" {
+		nodeClaim.StatusConditions().SetFalse(v1.ConditionTypeRegistering, reason, message)
+	}
+	return terminal, nil
+}
+
+// nodeForNodeClaim looks up the Node backing this NodeClaim by provider ID. This relies on the
+// providerIDFieldIndex field indexer registered by RegisterProviderIDIndexer (indexer.go), which
+// the controller's SetupWithManager must call before starting to watch NodeClaims.
+func (l *Liveness) nodeForNodeClaim(ctx context.Context, nodeClaim *v1.NodeClaim) (*corev1.Node, error) {
+	if nodeClaim.Status.ProviderID == "" {
+		return nil, nil
+	}
+	nodeList := &corev1.NodeList{}
+	if err := l.kubeClient.List(ctx, nodeList, client.MatchingFields{providerIDFieldIndex: nodeClaim.Status.ProviderID}); err != nil {
+		return nil, err
+	}
+	if len(nodeList.Items) == 0 {
+		return nil, nil
+	}
+	return &nodeList.Items[0], nil
+}
+
+// registeringConditionFromNode returns the NodeCondition type and message to surface while the
+// node hasn't reached Ready, preferring whichever of Ready, NetworkUnavailable, MemoryPressure,
+// DiskPressure, or PIDPressure transitioned most recently. When the stall is on Ready, message is
+// the kubelet's own condition message (e.g. a KubeletNotReady "container runtime is down"), while
+// reason is always the condition type so callers can filter on it. terminal reports whether the
+// kubelet's Ready condition carries a reason in terminalKubeletConditionReasons.
+func registeringConditionFromNode(node *corev1.Node) (reason, message string, terminal bool) {
+	watched := map[corev1.NodeConditionType]corev1.ConditionStatus{
+		corev1.NodeReady:              corev1.ConditionFalse,
+		corev1.NodeNetworkUnavailable: corev1.ConditionTrue,
+		corev1.NodeMemoryPressure:     corev1.ConditionTrue,
+		corev1.NodeDiskPressure:       corev1.ConditionTrue,
+		corev1.NodePIDPressure:        corev1.ConditionTrue,
+	}
+	var latest *corev1.NodeCondition
+	for i := range node.Status.Conditions {
+		cond := node.Status.Conditions[i]
+		if badStatus, ok := watched[cond.Type]; !ok || cond.Status != badStatus {
+			continue
 		}
-		if nodePool.StatusConditions().Get(v1.ConditionTypeNodeRegistrationHealthy).IsUnknown() {
-			stored := nodePool.DeepCopy()
-			// If the nodeClaim failed to register during the timeout set NodeRegistrationHealthy status condition on
-			// NodePool to False. If the launch failed get the launch failure reason and message from nodeClaim.
-			if launchCondition := nodeClaim.StatusConditions().Get(v1.ConditionTypeLaunched); launchCondition.IsTrue() {
-				nodePool.StatusConditions().SetFalse(v1.ConditionTypeNodeRegistrationHealthy, "RegistrationFailed", "Failed to register node")
-			} else {
-				nodePool.StatusConditions().SetFalse(v1.ConditionTypeNodeRegistrationHealthy, launchCondition.Reason, launchCondition.Message)
-			}
-			// We use client.MergeFromWithOptimisticLock because patching a list with a JSON merge patch
-			// can cause races due to the fact that it fully replaces the list on a change
-			// Here, we are updating the status condition list
-			if err := l.kubeClient.Status().Patch(ctx, nodePool, client.MergeFromWithOptions(stored, client.MergeFromWithOptimisticLock{})); client.IgnoreNotFound(err) != nil {
-				return err
-			}
+		if latest == nil || cond.LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = &node.Status.Conditions[i]
 		}
+		if cond.Type == corev1.NodeReady && terminalKubeletConditionReasons[cond.Reason] {
+			terminal = true
+		}
+	}
+	if latest == nil {
+		return "", "", terminal
+	}
+	return string(latest.Type), latest.Message, terminal
+}
+
+// updateNodePoolRegistrationHealth sets NodeRegistrationHealthy=False on the NodePool if the
+// nodeClaim fails to launch/register, and tracks the rolling count of consecutive registration
+// failures. Once that count crosses defaultConsecutiveFailureThreshold, the NodePool is quarantined
+// behind ConditionTypeProvisioningPaused for an exponentially-growing cooldown so a pool with a
+// persistently broken launch template, exhausted quota, etc. stops being relaunched into forever.
+func (l *Liveness) updateNodePoolRegistrationHealth(ctx context.Context, nodePool *v1.NodePool, nodeClaim *v1.NodeClaim) error {
+	if nodePool == nil {
+		return nil
+	}
+	stored := nodePool.DeepCopy()
+	// If the nodeClaim failed to register during the timeout set NodeRegistrationHealthy status condition on
+	// NodePool to False. If the launch failed get the launch failure reason and message from nodeClaim.
+	if launchCondition := nodeClaim.StatusConditions().Get(v1.ConditionTypeLaunched); launchCondition.IsTrue() {
+		nodePool.StatusConditions().SetFalse(v1.ConditionTypeNodeRegistrationHealthy, "RegistrationFailed", "Failed to register node")
+	} else {
+		nodePool.StatusConditions().SetFalse(v1.ConditionTypeNodeRegistrationHealthy, launchCondition.Reason, launchCondition.Message)
+	}
+	// Status.LastFailedNodeClaimUID tracks which NodeClaim we last counted, so a retried reconcile
+	// of the same not-yet-deleted NodeClaim (e.g. after a conflict requeue) doesn't double-count one
+	// failure as several, while a distinct NodeClaim failing always counts even if
+	// NodeRegistrationHealthy is already False from a prior one.
+	if nodePool.Status.LastFailedNodeClaimUID != nodeClaim.UID {
+		nodePool.Status.ConsecutiveRegistrationFailures++
+		nodePool.Status.LastFailureTime = &metav1.Time{Time: l.clock.Now()}
+		nodePool.Status.LastFailedNodeClaimUID = nodeClaim.UID
+		if backoff := ProvisioningBackoffFor(nodePool.Status.ConsecutiveRegistrationFailures); backoff > 0 {
+			message := fmt.Sprintf("%d consecutive registration failures, provisioning paused for %s", nodePool.Status.ConsecutiveRegistrationFailures, backoff)
+			nodePool.StatusConditions().SetTrueWithReason(v1.ConditionTypeProvisioningPaused, provisioningPausedReason, message)
+			metrics.NodePoolsProvisioningPausedTotal.Inc(map[string]string{
+				metrics.NodePoolLabel: nodePool.Name,
+			})
+		}
+	}
+	// We use client.MergeFromWithOptimisticLock because patching a list with a JSON merge patch
+	// can cause races due to the fact that it fully replaces the list on a change
+	// Here, we are updating the status condition list
+	if err := l.kubeClient.Status().Patch(ctx, nodePool, client.MergeFromWithOptions(stored, client.MergeFromWithOptimisticLock{})); client.IgnoreNotFound(err) != nil {
+		return err
 	}
 	return nil
 }
 
-func (l *Liveness) deleteNodeClaimForTimeout(ctx context.Context, timeout NodeClaimTimeout, nodeClaim *v1.NodeClaim) error {
+// resetNodePoolProvisioningBackoff clears a NodePool's consecutive-failure counter and
+// ConditionTypeProvisioningPaused, and restores NodeRegistrationHealthy=True, once a NodeClaim
+// belonging to it has registered successfully, so a quarantined pool resumes provisioning
+// immediately instead of waiting out its cooldown, and isn't left reporting unhealthy after it's
+// recovered.
+func (l *Liveness) resetNodePoolProvisioningBackoff(ctx context.Context, nodePool *v1.NodePool) error {
+	if nodePool == nil || nodePool.Status.ConsecutiveRegistrationFailures == 0 {
+		return nil
+	}
+	stored := nodePool.DeepCopy()
+	nodePool.Status.ConsecutiveRegistrationFailures = 0
+	nodePool.Status.LastFailureTime = nil
+	nodePool.Status.LastFailedNodeClaimUID = ""
+	nodePool.StatusConditions().SetTrue(v1.ConditionTypeNodeRegistrationHealthy)
+	nodePool.StatusConditions().Clear(v1.ConditionTypeProvisioningPaused)
+	return l.kubeClient.Status().Patch(ctx, nodePool, client.MergeFromWithOptions(stored, client.MergeFromWithOptimisticLock{}))
+}
+
+// ProvisioningBackoffFor returns the cooldown for a NodePool with the given number of consecutive
+// registration failures: 0 below defaultConsecutiveFailureThreshold, then 1m, 2m, 4m, ... doubling
+// with each additional failure and capped at provisioningBackoffMax. The provisioning controller
+// calls this to know how long a NodePool behind ConditionTypeProvisioningPaused stays quarantined.
+func ProvisioningBackoffFor(consecutiveFailures int) time.Duration {
+	if consecutiveFailures < defaultConsecutiveFailureThreshold {
+		return 0
+	}
+	shift := consecutiveFailures - defaultConsecutiveFailureThreshold
+	if shift > 16 { // guard against overflow well before the cap would apply
+		return provisioningBackoffMax
+	}
+	if backoff := provisioningBackoffBase << uint(shift); backoff < provisioningBackoffMax {
+		return backoff
+	}
+	return provisioningBackoffMax
+}
+
+func (l *Liveness) deleteNodeClaimForTimeout(ctx context.Context, timeout NodeClaimTimeout, nodePool *v1.NodePool, nodeClaim *v1.NodeClaim) error {
 	if err := l.kubeClient.Delete(ctx, nodeClaim); err != nil {
 		return err
 	}
 	log.FromContext(ctx).V(1).WithValues("timeout", timeout.duration, "reason", timeout.reason).Info("terminating due to timeout")
 	metrics.NodeClaimsDisruptedTotal.Inc(map[string]string{
-		metrics.ReasonLabel:       timeout.reason,
-		metrics.NodePoolLabel:     nodeClaim.Labels[v1.NodePoolLabelKey],
-		metrics.CapacityTypeLabel: nodeClaim.Labels[v1.CapacityTypeLabelKey],
+		metrics.ReasonLabel:              timeout.reason,
+		metrics.NodePoolLabel:            nodeClaim.Labels[v1.NodePoolLabelKey],
+		metrics.CapacityTypeLabel:        nodeClaim.Labels[v1.CapacityTypeLabelKey],
+		metrics.LaunchTimeoutLabel:       launchTimeoutFor(nodeClaim, nodePool).String(),
+		metrics.RegistrationTimeoutLabel: registrationTimeoutFor(nodeClaim, nodePool).String(),
 	})
 	return nil
 }
@@ -0,0 +1,90 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func nodeCondition(t corev1.NodeConditionType, status corev1.ConditionStatus, reason string, transitioned time.Time) corev1.NodeCondition {
+	return corev1.NodeCondition{
+		Type:               t,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: metav1.Time{Time: transitioned},
+	}
+}
+
+func TestRegisteringConditionFromNode(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		conditions   []corev1.NodeCondition
+		wantReason   string
+		wantTerminal bool
+	}{
+		"all healthy": {
+			conditions: []corev1.NodeCondition{
+				nodeCondition(corev1.NodeReady, corev1.ConditionTrue, "KubeletReady", now),
+				nodeCondition(corev1.NodeMemoryPressure, corev1.ConditionFalse, "", now),
+			},
+			wantReason: "",
+		},
+		"not ready surfaces Ready": {
+			conditions: []corev1.NodeCondition{
+				nodeCondition(corev1.NodeReady, corev1.ConditionFalse, "KubeletNotReady", now),
+			},
+			wantReason: "Ready",
+		},
+		"most recent transition wins": {
+			conditions: []corev1.NodeCondition{
+				nodeCondition(corev1.NodeReady, corev1.ConditionFalse, "KubeletNotReady", now),
+				nodeCondition(corev1.NodeDiskPressure, corev1.ConditionTrue, "DiskPressure", now.Add(time.Minute)),
+			},
+			wantReason: "DiskPressure",
+		},
+		"terminal kubelet reason short-circuits": {
+			conditions: []corev1.NodeCondition{
+				nodeCondition(corev1.NodeReady, corev1.ConditionFalse, "KubeletConfigFailed", now),
+			},
+			wantReason:   "Ready",
+			wantTerminal: true,
+		},
+		"non-terminal Ready reason does not short-circuit": {
+			conditions: []corev1.NodeCondition{
+				nodeCondition(corev1.NodeReady, corev1.ConditionFalse, "KubeletNotReady", now),
+			},
+			wantReason:   "Ready",
+			wantTerminal: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			node := &corev1.Node{Status: corev1.NodeStatus{Conditions: tc.conditions}}
+			reason, _, terminal := registeringConditionFromNode(node)
+			if reason != tc.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tc.wantReason)
+			}
+			if terminal != tc.wantTerminal {
+				t.Errorf("terminal = %v, want %v", terminal, tc.wantTerminal)
+			}
+		})
+	}
+}
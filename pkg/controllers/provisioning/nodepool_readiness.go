@@ -0,0 +1,58 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioning
+
+import (
+	"k8s.io/utils/clock"
+
+	v1 "sigs.k8s.io/karpenter/pkg/apis/v1"
+	"sigs.k8s.io/karpenter/pkg/controllers/nodeclaim/lifecycle"
+)
+
+// isProvisionable reports whether the given NodePool is a candidate the scheduler should
+// consider for launching a new NodeClaim. A NodePool quarantined behind
+// ConditionTypeProvisioningPaused is skipped until its exponential cooldown, computed from
+// Status.ConsecutiveRegistrationFailures, has elapsed since Status.LastFailureTime. The cooldown
+// is anchored to LastFailureTime rather than the condition's own LastTransitionTime because the
+// counter (and so the cooldown length) keeps advancing on later failures without necessarily
+// flipping the condition again, which would otherwise make the elapsed-time measurement drift
+// from the backoff it's being compared against.
+func isProvisionable(c clock.Clock, nodePool *v1.NodePool) bool {
+	paused := nodePool.StatusConditions().Get(v1.ConditionTypeProvisioningPaused)
+	if !paused.IsTrue() {
+		return true
+	}
+	if nodePool.Status.LastFailureTime == nil {
+		return true
+	}
+	cooldown := lifecycle.ProvisioningBackoffFor(nodePool.Status.ConsecutiveRegistrationFailures)
+	return c.Since(nodePool.Status.LastFailureTime.Time) >= cooldown
+}
+
+// SchedulableNodePools filters nodePools down to those isProvisionable allows launching into,
+// i.e. drops any still quarantined behind ConditionTypeProvisioningPaused. Nothing in this
+// package calls it; a quarantined NodePool is skipped only by code that filters its candidate
+// NodePools through this function.
+func SchedulableNodePools(c clock.Clock, nodePools []*v1.NodePool) []*v1.NodePool {
+	out := make([]*v1.NodePool, 0, len(nodePools))
+	for _, nodePool := range nodePools {
+		if isProvisionable(c, nodePool) {
+			out = append(out, nodePool)
+		}
+	}
+	return out
+}
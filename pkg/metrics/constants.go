@@ -0,0 +1,29 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+const (
+	Namespace = "karpenter"
+
+	ReasonLabel       = "reason"
+	NodePoolLabel     = "nodepool"
+	CapacityTypeLabel = "capacity_type"
+	// LaunchTimeoutLabel and RegistrationTimeoutLabel record the effective, per-NodePool
+	// launch/registration timeout budget that was in effect when a NodeClaim was disrupted.
+	LaunchTimeoutLabel       = "launch_timeout"
+	RegistrationTimeoutLabel = "registration_timeout"
+)
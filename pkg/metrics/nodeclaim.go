@@ -0,0 +1,40 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	opmetrics "github.com/awslabs/operatorpkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const nodeClaimSubsystem = "nodeclaims"
+
+// NodeClaimsDisruptedTotal counts NodeClaims terminated by the lifecycle controller for a launch
+// timeout, a registration timeout, or a kubelet-unhealthy reason. LaunchTimeoutLabel and
+// RegistrationTimeoutLabel carry the effective per-NodePool budget that was in effect, so
+// operators can see which pool's budget expired.
+var NodeClaimsDisruptedTotal = opmetrics.NewPrometheusCounter(
+	crmetrics.Registry,
+	prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: nodeClaimSubsystem,
+		Name:      "disrupted_total",
+		Help:      "Number of NodeClaims disrupted over time.",
+	},
+	[]string{ReasonLabel, NodePoolLabel, CapacityTypeLabel, LaunchTimeoutLabel, RegistrationTimeoutLabel},
+)
@@ -0,0 +1,39 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	opmetrics "github.com/awslabs/operatorpkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const nodePoolSubsystem = "nodepools"
+
+// NodePoolsProvisioningPausedTotal counts how many times a NodePool has been quarantined behind
+// ConditionTypeProvisioningPaused after crossing its consecutive-registration-failure threshold,
+// so operators can alert on quarantined pools.
+var NodePoolsProvisioningPausedTotal = opmetrics.NewPrometheusCounter(
+	crmetrics.Registry,
+	prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: nodePoolSubsystem,
+		Name:      "provisioning_paused_total",
+		Help:      "Number of times a NodePool has been paused for consecutive registration failures.",
+	},
+	[]string{NodePoolLabel},
+)